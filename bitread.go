@@ -6,10 +6,20 @@ package bitread
 import (
 	"bytes"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
 )
 
+// ErrChunkOverrun is returned by TryEndChunk (and causes EndChunk to panic) when reading
+// exceeded the boundary of the current chunk.
+var ErrChunkOverrun = errors.New("bitread: read beyond a chunk boundary")
+
+// ErrInvalidBuffer is returned by TryOpen, TryOpenWithBuffer and TryOpenWithOptions (and causes
+// their panicking counterparts to panic) when the configured buffer is not a multiple of 8 bytes
+// or not larger than 16 bytes.
+var ErrInvalidBuffer = errors.New("bitread: invalid buffer size")
+
 const (
 	sled     = 8
 	sledMask = sled - 1
@@ -38,6 +48,37 @@ func (s stack) top() int {
 	return s[len(s)-1]
 }
 
+// BitOrder determines the order in which bits are consumed from each byte of the underlying reader.
+type BitOrder int
+
+const (
+	// LSBFirst reads bit 0 of a byte first. This is the default and matches the Source engine demo format.
+	LSBFirst BitOrder = iota
+
+	// MSBFirst reads bit 7 of a byte first, as used by e.g. Deflate, Brotli, JPEG, H.264, FLAC and MP3.
+	MSBFirst
+)
+
+// BitReaderOptions are used with OpenWithOptions to configure a BitReader beyond just the buffer.
+type BitReaderOptions struct {
+	// BufferSize is the size of the internal buffer to allocate, in bytes.
+	// Must be a multiple of 8 and > 16. Ignored if Buffer is set.
+	BufferSize int
+
+	// Buffer is the internal byte buffer to use, see OpenWithBuffer.
+	// Overrides BufferSize if set.
+	Buffer []byte
+
+	// BitOrder sets the order in which bits are read from each byte of the underlying reader.
+	// Defaults to LSBFirst.
+	BitOrder BitOrder
+}
+
+var (
+	_ io.Reader     = (*BitReader)(nil)
+	_ io.ByteReader = (*BitReader)(nil)
+)
+
 // BitReader wraps an io.Reader and provides methods to read from it on the bit level.
 type BitReader struct {
 	underlying   io.Reader
@@ -47,6 +88,9 @@ type BitReader struct {
 	lazyPosition int
 	chunkTargets stack
 	endReached   bool
+	bitOrder     BitOrder
+	err          error // Sticky error, set once a read fails; all further Try* reads short-circuit on it.
+	totalBits    int64 // Bits remaining from the Open position to the end, probed via io.Seeker on Open. -1 if unknown.
 }
 
 // LazyPosition returns the offset at the time of the last time the buffer was refilled.
@@ -62,35 +106,84 @@ func (r *BitReader) ActualPosition() int {
 // Open sets the underlying io.Reader and internal buffer, making the reader ready to use.
 // bufferSize is in bytes, must be a multiple of 8 and > 16.
 func (r *BitReader) Open(underlying io.Reader, bufferSize int) {
-	r.OpenWithBuffer(underlying, make([]byte, bufferSize))
+	if err := r.TryOpen(underlying, bufferSize); err != nil {
+		panic(err)
+	}
+}
+
+// TryOpen is like Open but returns an error instead of panicking.
+func (r *BitReader) TryOpen(underlying io.Reader, bufferSize int) error {
+	return r.TryOpenWithBuffer(underlying, make([]byte, bufferSize))
 }
 
 // OpenWithBuffer is like Open but allows to provide the internal byte buffer.
 // Could be useful to pool buffers of short living BitReaders for example.
 // len(buffer) must be a multiple of 8 and > 16.
 func (r *BitReader) OpenWithBuffer(underlying io.Reader, buffer []byte) {
+	if err := r.TryOpenWithBuffer(underlying, buffer); err != nil {
+		panic(err)
+	}
+}
+
+// TryOpenWithBuffer is like OpenWithBuffer but returns an error instead of panicking.
+func (r *BitReader) TryOpenWithBuffer(underlying io.Reader, buffer []byte) error {
+	return r.TryOpenWithOptions(underlying, BitReaderOptions{Buffer: buffer})
+}
+
+// OpenWithOptions is like OpenWithBuffer but additionally allows configuring the BitOrder
+// and other options via BitReaderOptions.
+func (r *BitReader) OpenWithOptions(underlying io.Reader, opts BitReaderOptions) {
+	if err := r.TryOpenWithOptions(underlying, opts); err != nil {
+		panic(err)
+	}
+}
+
+// TryOpenWithOptions is like OpenWithOptions but returns an error instead of panicking.
+func (r *BitReader) TryOpenWithOptions(underlying io.Reader, opts BitReaderOptions) error {
+	buffer := opts.Buffer
+	if buffer == nil {
+		buffer = make([]byte, opts.BufferSize)
+	}
 	if len(buffer)&sledMask != 0 {
-		panic(fmt.Sprintf("Buffer must be a multiple of %d", sled))
+		return fmt.Errorf("%w: buffer must be a multiple of %d", ErrInvalidBuffer, sled)
 	}
 	if len(buffer) <= sled<<1 {
-		panic(fmt.Sprintf("Buffer must be larger than %d bytes", sled<<1))
+		return fmt.Errorf("%w: buffer must be larger than %d bytes", ErrInvalidBuffer, sled<<1)
 	}
 
+	r.bitOrder = opts.BitOrder
 	r.endReached = false
 	r.underlying = underlying
 	r.buffer = buffer
+	r.err = nil
+	r.totalBits = -1
+
+	// Probe the number of bits remaining from the current position of underlying, if it
+	// supports seeking; this is what BitLen/Len/SeekBits(_, io.SeekEnd) report and seek
+	// relative to, not the total size of underlying's complete contents.
+	if seeker, ok := underlying.(io.Seeker); ok {
+		if cur, err := seeker.Seek(0, io.SeekCurrent); err == nil {
+			if end, err := seeker.Seek(0, io.SeekEnd); err == nil {
+				if _, err := seeker.Seek(cur, io.SeekStart); err == nil {
+					r.totalBits = (end - cur) << 3
+				}
+			}
+		}
+	}
 
 	// Initialize buffer
-	bytes, err := r.underlying.Read(r.buffer)
+	bytesRead, err := r.underlying.Read(r.buffer)
 	if err != nil {
-		panic(err)
+		return err
 	}
 
-	r.bitsInBuffer = (bytes << 3) - sledBits
+	r.bitsInBuffer = (bytesRead << 3) - sledBits
 	if r.bitsInBuffer < 0 {
 		// All bytes read already
 		r.bitsInBuffer += sledBits
 	}
+
+	return nil
 }
 
 // Close resets the BitReader. Open() may be used again after Close().
@@ -105,23 +198,47 @@ func (r *BitReader) Close() error {
 	r.bitsInBuffer = 0
 	r.chunkTargets = stack{}
 	r.lazyPosition = 0
+	r.err = nil
+	r.totalBits = -1
 
 	return nil
 }
 
 // ReadBit reads a single bit.
 func (r *BitReader) ReadBit() bool {
-	res := (r.buffer[r.offset>>3] & (1 << uint(r.offset&7))) != 0
-	r.advance(1)
+	res, err := r.TryReadBit()
+	if err != nil {
+		panic(err)
+	}
 	return res
 }
 
+// TryReadBit is like ReadBit but returns an error instead of panicking.
+func (r *BitReader) TryReadBit() (bool, error) {
+	if r.err != nil {
+		return false, r.err
+	}
+
+	var res bool
+	if r.bitOrder == MSBFirst {
+		res = (r.buffer[r.offset>>3] & (1 << uint(7-r.offset&7))) != 0
+	} else {
+		res = (r.buffer[r.offset>>3] & (1 << uint(r.offset&7))) != 0
+	}
+
+	return res, r.advance(1)
+}
+
 // ReadBits reads n bits into a []byte.
 func (r *BitReader) ReadBits(n int) []byte {
 	b := make([]byte, (n+7)>>3)
 	bitLevel := r.offset&7 != 0
 	for i := 0; i < n>>3; i++ {
-		b[i] = r.readByteInternal(bitLevel)
+		v, err := r.tryReadByteInternal(bitLevel)
+		if err != nil {
+			panic(err)
+		}
+		b[i] = v
 	}
 	if n&7 != 0 {
 		b[n>>3] = r.ReadBitsToByte(n & 7)
@@ -132,16 +249,68 @@ func (r *BitReader) ReadBits(n int) []byte {
 // ReadSingleByte reads one byte.
 // Not called ReadByte as it does not comply with the standard library interface.
 func (r *BitReader) ReadSingleByte() byte {
-	return r.readByteInternal(r.offset&7 != 0)
+	res, err := r.tryReadByteInternal(r.offset&7 != 0)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// ReadByte implements io.ByteReader.
+func (r *BitReader) ReadByte() (byte, error) {
+	return r.tryReadByteInternal(r.offset&7 != 0)
+}
+
+// Read implements io.Reader. Whole buffered bytes are copied directly when the reader is
+// byte-aligned; otherwise (or once the buffer is drained) it falls back to reading a single
+// byte per call, via bit-level shifts if the reader is not byte-aligned.
+func (r *BitReader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	// Unlike the bit-level Try* API, plain Read is expected to signal running out of data
+	// with a plain io.EOF, not io.ErrUnexpectedEOF.
+	if r.err != nil {
+		if r.err == io.ErrUnexpectedEOF {
+			return 0, io.EOF
+		}
+		return 0, r.err
+	}
+
+	if r.offset&7 == 0 {
+		if available := (r.bitsInBuffer - r.offset) >> 3; available > 0 {
+			n := len(p)
+			if n > available {
+				n = available
+			}
+			copy(p[:n], r.buffer[r.offset>>3:(r.offset>>3)+n])
+			if err := r.advance(n << 3); err != nil {
+				return n, nil // The buffer holds all n bytes already; surface the error on the next call.
+			}
+			return n, nil
+		}
+	}
+
+	b, err := r.tryReadByteInternal(r.offset&7 != 0)
+	if err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return 0, io.EOF
+		}
+		return 0, err
+	}
+	p[0] = b
+	return 1, nil
 }
 
-func (r *BitReader) readByteInternal(bitLevel bool) byte {
+func (r *BitReader) tryReadByteInternal(bitLevel bool) (byte, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
 	if !bitLevel {
 		res := r.buffer[r.offset>>3]
-		r.advance(8)
-		return res
+		return res, r.advance(8)
 	}
-	return r.ReadBitsToByte(8)
+	return r.TryReadBitsToByte(8)
 }
 
 // ReadBitsToByte reads n bits into a byte.
@@ -150,22 +319,47 @@ func (r *BitReader) ReadBitsToByte(n int) byte {
 	return byte(r.ReadInt(n))
 }
 
+// TryReadBitsToByte is like ReadBitsToByte but returns an error instead of panicking.
+func (r *BitReader) TryReadBitsToByte(n int) (byte, error) {
+	v, err := r.TryReadInt(n)
+	return byte(v), err
+}
+
 // ReadInt reads the next n bits as an int.
 // Undefined for n > 32.
 func (r *BitReader) ReadInt(n int) uint {
+	res, err := r.TryReadInt(n)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// TryReadInt is like ReadInt but returns an error instead of panicking.
+func (r *BitReader) TryReadInt(n int) (uint, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+
 	byteIndex := r.offset >> 3
 	bitOffset := r.offset & 7
 
-	val := binary.LittleEndian.Uint64(r.buffer[byteIndex:])
-
-	res := uint(val>>bitOffset) & ((1 << n) - 1)
+	var res uint
+	if r.bitOrder == MSBFirst {
+		val := binary.BigEndian.Uint64(r.buffer[byteIndex:])
+		res = uint(val>>uint(64-bitOffset-n)) & ((1 << uint(n)) - 1)
+	} else {
+		val := binary.LittleEndian.Uint64(r.buffer[byteIndex:])
+		res = uint(val>>bitOffset) & ((1 << n) - 1)
+	}
 
 	r.offset += n
+	var err error
 	if r.offset > r.bitsInBuffer {
-		r.refillBuffer()
+		err = r.refillBuffer()
 	}
 
-	return res
+	return res, err
 }
 
 // ReadBytes reads n bytes.
@@ -176,19 +370,46 @@ func (r *BitReader) ReadBytes(n int) []byte {
 	return res
 }
 
+// TryReadBytes is like ReadBytes but returns an error instead of panicking.
+func (r *BitReader) TryReadBytes(n int) ([]byte, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	res := make([]byte, 0, n)
+	if err := r.tryReadBytesInto(&res, n); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
 // ReadBytesInto reads n bytes into out.
 // Useful for pooling []byte slices.
 func (r *BitReader) ReadBytesInto(out *[]byte, n int) {
+	if err := r.tryReadBytesInto(out, n); err != nil {
+		panic(err)
+	}
+}
+
+func (r *BitReader) tryReadBytesInto(out *[]byte, n int) error {
+	if r.err != nil {
+		return r.err
+	}
+
 	bitLevel := r.offset&7 != 0
 	if !bitLevel && r.offset+(n<<3) <= r.bitsInBuffer {
 		// Shortcut if offset%8 = 0 and all bytes are already buffered
 		*out = append(*out, r.buffer[r.offset>>3:(r.offset>>3)+n]...)
-		r.advance(n << 3)
-	} else {
-		for i := 0; i < n; i++ {
-			*out = append(*out, r.readByteInternal(bitLevel))
+		return r.advance(n << 3)
+	}
+
+	for i := 0; i < n; i++ {
+		b, err := r.tryReadByteInternal(bitLevel)
+		if err != nil {
+			return err
 		}
+		*out = append(*out, b)
 	}
+	return nil
 }
 
 // ReadCString reads n bytes as characters into a string.
@@ -205,13 +426,33 @@ func (r *BitReader) ReadCString(n int) string {
 // ReadSignedInt is like ReadInt but returns signed int.
 // Undefined for n > 32.
 func (r *BitReader) ReadSignedInt(n int) int {
-	val := binary.LittleEndian.Uint64(r.buffer[r.offset>>3&^3:])
-	// Cast to int64 before right shift & use offset before advance
-	res := int(int64(val<<uint(64-(r.offset&31)-n)) >> (64 - uint(n)))
-	r.advance(n)
+	res, err := r.TryReadSignedInt(n)
+	if err != nil {
+		panic(err)
+	}
 	return res
 }
 
+// TryReadSignedInt is like ReadSignedInt but returns an error instead of panicking.
+func (r *BitReader) TryReadSignedInt(n int) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+
+	var res int
+	if r.bitOrder == MSBFirst {
+		val := binary.BigEndian.Uint64(r.buffer[r.offset>>3&^3:])
+		// Cast to int64 before right shift & use offset before advance
+		res = int(int64(val<<uint(r.offset&31)) >> (64 - uint(n)))
+	} else {
+		val := binary.LittleEndian.Uint64(r.buffer[r.offset>>3&^3:])
+		// Cast to int64 before right shift & use offset before advance
+		res = int(int64(val<<uint(64-(r.offset&31)-n)) >> (64 - uint(n)))
+	}
+
+	return res, r.advance(n)
+}
+
 // BeginChunk starts a new chunk with n bits.
 // Useful to make sure the position in the bit stream is correct.
 func (r *BitReader) BeginChunk(n int) {
@@ -222,17 +463,28 @@ func (r *BitReader) BeginChunk(n int) {
 // Seeks to the end of the chunk if not already reached.
 // Panics if the chunk boundary was exceeded while reading.
 func (r *BitReader) EndChunk() {
+	if err := r.TryEndChunk(); err != nil {
+		panic(err)
+	}
+}
+
+// TryEndChunk is like EndChunk but returns ErrChunkOverrun (or a Skip error) instead of
+// panicking when the chunk boundary was exceeded while reading.
+func (r *BitReader) TryEndChunk() error {
 	var target int
 	r.chunkTargets, target = r.chunkTargets.pop()
 	delta := target - r.ActualPosition()
 	if delta < 0 {
-		panic("Someone read beyond a chunk boundary, what a dick")
+		return ErrChunkOverrun
 	} else if delta > 0 {
-		r.Skip(delta)
+		if err := r.TrySkip(delta); err != nil {
+			return err
+		}
 	}
 	if target != r.ActualPosition() {
 		panic(fmt.Sprintf("Skipping data failed, expected position %d got %d", target, r.ActualPosition()))
 	}
+	return nil
 }
 
 // ChunkFinished returns true if the current position is at the end of the chunk.
@@ -242,6 +494,17 @@ func (r *BitReader) ChunkFinished() bool {
 
 // Skip skips n bits.
 func (r *BitReader) Skip(n int) {
+	if err := r.TrySkip(n); err != nil {
+		panic(err)
+	}
+}
+
+// TrySkip is like Skip but returns an error instead of panicking.
+func (r *BitReader) TrySkip(n int) error {
+	if r.err != nil {
+		return r.err
+	}
+
 	// Seek for the end of the chunk
 	bufferBits := r.bitsInBuffer - r.offset
 	seeker, ok := r.underlying.(io.Seeker)
@@ -250,13 +513,15 @@ func (r *BitReader) Skip(n int) {
 		unbufferedSkipBits := n - bufferBits
 		globalOffset, err := seeker.Seek(int64((unbufferedSkipBits>>3)-sled), io.SeekCurrent)
 		if err != nil {
-			panic(err)
+			r.err = err
+			return err
 		}
 		r.lazyPosition = int(globalOffset) << 3
 
 		newBytes, err := r.underlying.Read(r.buffer)
 		if err != nil {
-			panic(err)
+			r.err = err
+			return err
 		}
 		r.offset = unbufferedSkipBits & sledMask
 
@@ -268,21 +533,79 @@ func (r *BitReader) Skip(n int) {
 			// In that case bitsInBuffer should be 0 after this line (newBytes=0 - sled + sled)
 			r.bitsInBuffer += sledBits
 		}
-	} else {
-		// Can't seek or no seek necessary
-		r.advance(n)
+		return nil
+	}
+
+	// Can't seek or no seek necessary
+	return r.advance(n)
+}
+
+// SeekBits generalizes Skip to io.SeekStart, io.SeekCurrent and io.SeekEnd semantics, in
+// bits rather than bytes. Requires the underlying reader to implement io.Seeker, and (for
+// io.SeekEnd) its length to have been determined successfully on Open. Seeking backwards is
+// not supported.
+func (r *BitReader) SeekBits(offset int64, whence int) (int64, error) {
+	if _, ok := r.underlying.(io.Seeker); !ok {
+		return 0, errors.New("bitread: underlying reader does not implement io.Seeker")
+	}
+
+	var target int64
+	switch whence {
+	case io.SeekStart:
+		target = offset
+	case io.SeekCurrent:
+		target = int64(r.ActualPosition()) + offset
+	case io.SeekEnd:
+		if r.totalBits < 0 {
+			return 0, errors.New("bitread: length of underlying reader is unknown")
+		}
+		target = r.totalBits + offset
+	default:
+		return 0, fmt.Errorf("bitread: invalid whence %d", whence)
+	}
+
+	if target < int64(r.ActualPosition()) {
+		return 0, errors.New("bitread: SeekBits does not support seeking backwards")
+	}
+
+	if delta := target - int64(r.ActualPosition()); delta > 0 {
+		if err := r.TrySkip(int(delta)); err != nil {
+			return 0, err
+		}
+	}
+
+	return int64(r.ActualPosition()), nil
+}
+
+// BitLen returns the number of bits remaining from the position the underlying reader was at
+// when Open was called to its end, if it implements io.Seeker and its length could be
+// determined at that time. Returns -1 otherwise.
+func (r *BitReader) BitLen() int64 {
+	return r.totalBits
+}
+
+// Len returns the number of bytes remaining from the position the underlying reader was at
+// when Open was called to its end, if it implements io.Seeker and its length could be
+// determined at that time. Returns -1 otherwise.
+func (r *BitReader) Len() int64 {
+	if r.totalBits < 0 {
+		return -1
 	}
+	return r.totalBits >> 3
 }
 
-func (r *BitReader) advance(bits int) {
+func (r *BitReader) advance(bits int) error {
 	r.offset += bits
 	for r.offset > r.bitsInBuffer {
 		// Refill if we reached the sled
-		r.refillBuffer()
+		if err := r.refillBuffer(); err != nil {
+			return err
+		}
 	}
+	return nil
 }
 
-func (r *BitReader) refillBuffer() {
+func (r *BitReader) refillBuffer() error {
 	// Copy sled to beginning
 	copy(r.buffer[0:sled], r.buffer[r.bitsInBuffer>>3:(r.bitsInBuffer>>3)+sled])
 
@@ -296,13 +619,17 @@ func (r *BitReader) refillBuffer() {
 		if err == io.EOF {
 			if r.endReached {
 				// Read beyond end of underlying Reader
-				panic(io.ErrUnexpectedEOF)
+				r.err = io.ErrUnexpectedEOF
+				return r.err
 			}
 			// We're done here, consume sled
 			r.bitsInBuffer += sledBits
 			r.endReached = true
 		} else {
-			panic(err)
+			r.err = err
+			return err
 		}
 	}
+
+	return nil
 }
@@ -3,7 +3,9 @@ package bitread_test
 import (
 	"bytes"
 	"encoding/binary"
+	"errors"
 	"fmt"
+	"io"
 	"math"
 	"testing"
 
@@ -42,6 +44,38 @@ func TestReadBit(t *testing.T) {
 	}
 }
 
+func TestReadBitMSBFirst(t *testing.T) {
+	b := make([]byte, 0xff)
+	for n := byte(0); n < byte(len(b)); n++ {
+		b[n] = n
+	}
+
+	br := new(bitread.BitReader)
+	br.OpenWithOptions(bytes.NewReader(b), bitread.BitReaderOptions{BufferSize: 32, BitOrder: bitread.MSBFirst})
+
+	res := make([]byte, 8)
+	var exp string
+	for i := 0; i < len(b); i++ {
+		for i := 0; i < 8; i++ {
+			// Most significant bit first
+			if br.ReadBit() {
+				res[i] = '1'
+			} else {
+				res[i] = '0'
+			}
+		}
+
+		exp = fmt.Sprintf("%b", b[i])
+		// Pad cut off bits
+		for len(exp) < 8 {
+			exp = "0" + exp
+		}
+		if string(res) != exp {
+			t.Fatalf("Expected %s got %s", exp, res)
+		}
+	}
+}
+
 func TestReadBytes(t *testing.T) {
 	b := make([]byte, 1<<8)
 	for n := 0; n < len(b); n++ {
@@ -145,6 +179,24 @@ func TestReadInt(t *testing.T) {
 	}
 }
 
+func TestReadIntMSBFirst(t *testing.T) {
+	nums := []uint32{0, math.MaxUint32, 0x61cb83f0}
+	b := make([]byte, len(nums)<<2)
+	for i := 0; i < len(nums); i++ {
+		binary.BigEndian.PutUint32(b[i<<2:], nums[i])
+	}
+
+	br := new(bitread.BitReader)
+	br.OpenWithOptions(bytes.NewReader(b), bitread.BitReaderOptions{BufferSize: 32, BitOrder: bitread.MSBFirst})
+
+	for i := 0; i < len(nums); i++ {
+		r := br.ReadInt(32)
+		if r != uint(nums[i]) {
+			t.Fatalf("Expected %q got %q", nums[i], r)
+		}
+	}
+}
+
 func TestReadSignedInt(t *testing.T) {
 	nums := []int32{math.MaxInt32, math.MinInt32, 0, 0x4ac71bf}
 	b := make([]byte, len(nums)<<2)
@@ -163,6 +215,24 @@ func TestReadSignedInt(t *testing.T) {
 	}
 }
 
+func TestReadSignedIntMSBFirst(t *testing.T) {
+	nums := []int32{math.MaxInt32, math.MinInt32, 0, 0x4ac71bf}
+	b := make([]byte, len(nums)<<2)
+	for i := 0; i < len(nums); i++ {
+		binary.BigEndian.PutUint32(b[i<<2:], uint32(nums[i]))
+	}
+
+	br := new(bitread.BitReader)
+	br.OpenWithOptions(bytes.NewReader(b), bitread.BitReaderOptions{BufferSize: 32, BitOrder: bitread.MSBFirst})
+
+	for i := 0; i < len(nums); i++ {
+		r := br.ReadSignedInt(32)
+		if r != int(nums[i]) {
+			t.Fatalf("Expected %q got %q", nums[i], r)
+		}
+	}
+}
+
 func TestPositions(t *testing.T) {
 	b := []byte{0xac}
 
@@ -261,6 +331,136 @@ func TestChunkSeek(t *testing.T) {
 	}
 }
 
+func TestRead(t *testing.T) {
+	b := make([]byte, 1<<8)
+	for n := 0; n < len(b); n++ {
+		b[n] = byte(n)
+	}
+
+	br := new(bitread.BitReader)
+	br.Open(bytes.NewReader(b), 32)
+
+	var out bytes.Buffer
+	if _, err := io.Copy(&out, br); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	if !bytes.Equal(out.Bytes(), b) {
+		t.Fatalf("Expected %x got %x", b, out.Bytes())
+	}
+}
+
+func TestReadByte(t *testing.T) {
+	b := []byte{0x12, 0x34}
+
+	br := new(bitread.BitReader)
+	br.Open(bytes.NewReader(b), 32)
+
+	r, err := br.ReadByte()
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if r != 0x12 {
+		t.Fatalf("Expected %#x got %#x", 0x12, r)
+	}
+}
+
+func TestBitLen(t *testing.T) {
+	b := []byte{0x12, 0x34, 0x56}
+
+	br := new(bitread.BitReader)
+	br.Open(bytes.NewReader(b), 32)
+
+	if br.BitLen() != int64(len(b)<<3) {
+		t.Fatalf("Expected %d got %d", len(b)<<3, br.BitLen())
+	}
+	if br.Len() != int64(len(b)) {
+		t.Fatalf("Expected %d got %d", len(b), br.Len())
+	}
+}
+
+func TestSeekBits(t *testing.T) {
+	b := []byte{0x12, 0x34, 0x56, 0x78}
+
+	br := new(bitread.BitReader)
+	br.Open(bytes.NewReader(b), 32)
+
+	if _, err := br.SeekBits(16, io.SeekStart); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if br.ReadSingleByte() != 0x56 {
+		t.Fatal("Expected to have seeked to the 3rd byte")
+	}
+
+	if _, err := br.SeekBits(-8, io.SeekEnd); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if br.ReadSingleByte() != 0x78 {
+		t.Fatal("Expected to have seeked to the last byte")
+	}
+}
+
+func TestTryReadBytesEOF(t *testing.T) {
+	b := []byte{0x01, 0x02, 0x03, 0x04}
+
+	br := new(bitread.BitReader)
+	br.Open(bytes.NewReader(b), 32)
+
+	if _, err := br.TryReadBytes(4); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	if _, err := br.TryReadBytes(100); err != io.ErrUnexpectedEOF {
+		t.Fatalf("Expected io.ErrUnexpectedEOF got %v", err)
+	}
+
+	// Sticky error should short-circuit further reads too.
+	if _, err := br.TryReadBit(); err != io.ErrUnexpectedEOF {
+		t.Fatalf("Expected io.ErrUnexpectedEOF got %v", err)
+	}
+}
+
+func TestTryEndChunkOverrun(t *testing.T) {
+	b := []byte("a")
+
+	br := new(bitread.BitReader)
+	br.Open(bytes.NewReader(b), 32)
+
+	br.BeginChunk(0)
+	br.ReadBit()
+
+	if err := br.TryEndChunk(); err != bitread.ErrChunkOverrun {
+		t.Fatalf("Expected ErrChunkOverrun got %v", err)
+	}
+}
+
+func TestTrySkip(t *testing.T) {
+	b := []byte{0x01, 0x02}
+
+	br := new(bitread.BitReader)
+	br.Open(bytes.NewReader(b), 32)
+
+	if err := br.TrySkip(8); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	if br.ReadSingleByte() != 0x02 {
+		t.Fatal("Expected to have skipped the first byte")
+	}
+}
+
+func TestTryOpenInvalidBuffer(t *testing.T) {
+	br := new(bitread.BitReader)
+
+	if err := br.TryOpen(bytes.NewReader([]byte{0x01}), 4); !errors.Is(err, bitread.ErrInvalidBuffer) {
+		t.Fatalf("Expected ErrInvalidBuffer got %v", err)
+	}
+
+	if err := br.TryOpenWithBuffer(bytes.NewReader([]byte{0x01}), make([]byte, 7)); !errors.Is(err, bitread.ErrInvalidBuffer) {
+		t.Fatalf("Expected ErrInvalidBuffer got %v", err)
+	}
+}
+
 func TestChunkExceeded(t *testing.T) {
 	b := []byte("a")
 
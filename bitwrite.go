@@ -0,0 +1,174 @@
+package bitread
+
+import (
+	"fmt"
+	"io"
+)
+
+// BitWriter wraps an io.Writer and provides methods to write to it on the bit level.
+// It mirrors the buffering strategy of BitReader: bits accumulate in an internal buffer
+// and completed bytes are flushed to the underlying writer once the buffer fills up, while
+// a trailing partial byte is carried over to the front of the buffer, much like the
+// BitReader's sled.
+type BitWriter struct {
+	underlying io.Writer
+	buffer     []byte
+	offset     int // Bit offset into buffer.
+	bitOrder   BitOrder
+}
+
+// BitWriterOptions are used with OpenWithOptions to configure a BitWriter.
+type BitWriterOptions struct {
+	// BufferSize is the size of the internal buffer to allocate, in bytes.
+	// Must be a multiple of 8 and > 0.
+	BufferSize int
+
+	// BitOrder sets the order in which bits are packed into each byte written to the underlying writer.
+	// Defaults to LSBFirst.
+	BitOrder BitOrder
+}
+
+// Open sets the underlying io.Writer and internal buffer, making the writer ready to use.
+// bufferSize is in bytes, must be a multiple of 8 and > 0.
+func (w *BitWriter) Open(underlying io.Writer, bufferSize int) {
+	w.OpenWithOptions(underlying, BitWriterOptions{BufferSize: bufferSize})
+}
+
+// OpenWithOptions is like Open but additionally allows configuring the BitOrder.
+func (w *BitWriter) OpenWithOptions(underlying io.Writer, opts BitWriterOptions) {
+	if opts.BufferSize&sledMask != 0 {
+		panic(fmt.Sprintf("Buffer must be a multiple of %d", sled))
+	}
+	if opts.BufferSize <= 0 {
+		panic("Buffer must be larger than 0 bytes")
+	}
+
+	w.underlying = underlying
+	w.buffer = make([]byte, opts.BufferSize)
+	w.offset = 0
+	w.bitOrder = opts.BitOrder
+}
+
+// WriteBit writes a single bit.
+func (w *BitWriter) WriteBit(bit bool) {
+	if w.offset&7 == 0 {
+		w.buffer[w.offset>>3] = 0
+	}
+	if bit {
+		if w.bitOrder == MSBFirst {
+			w.buffer[w.offset>>3] |= 1 << uint(7-w.offset&7)
+		} else {
+			w.buffer[w.offset>>3] |= 1 << uint(w.offset&7)
+		}
+	}
+	w.advance(1)
+}
+
+// WriteInt writes the lower n bits of v.
+// Undefined for n > 32.
+func (w *BitWriter) WriteInt(v uint, n int) {
+	if w.bitOrder == MSBFirst {
+		for i := n - 1; i >= 0; i-- {
+			w.WriteBit((v>>uint(i))&1 != 0)
+		}
+		return
+	}
+	for i := 0; i < n; i++ {
+		w.WriteBit(v&1 != 0)
+		v >>= 1
+	}
+}
+
+// WriteSignedInt is like WriteInt but takes a signed int.
+// Undefined for n > 32.
+func (w *BitWriter) WriteSignedInt(v int, n int) {
+	w.WriteInt(uint(v)&((1<<uint(n))-1), n)
+}
+
+// WriteBits writes the first n bits of b.
+func (w *BitWriter) WriteBits(b []byte, n int) {
+	for i := 0; i < n>>3; i++ {
+		w.writeByteInternal(b[i])
+	}
+	if n&7 != 0 {
+		w.WriteInt(uint(b[n>>3]), n&7)
+	}
+}
+
+// WriteBytes writes b in full.
+func (w *BitWriter) WriteBytes(b []byte) {
+	for _, v := range b {
+		w.writeByteInternal(v)
+	}
+}
+
+// WriteCString writes s as n bytes, zero-padding it (or truncating it) as necessary.
+// Mirrors ReadCString.
+func (w *BitWriter) WriteCString(s string, n int) {
+	b := make([]byte, n)
+	copy(b, s)
+	w.WriteBytes(b)
+}
+
+func (w *BitWriter) writeByteInternal(b byte) {
+	if w.offset&7 == 0 {
+		w.buffer[w.offset>>3] = b
+		w.advance(8)
+		return
+	}
+	w.WriteInt(uint(b), 8)
+}
+
+// Flush writes all buffered complete bytes to the underlying writer.
+// Call Close instead when done writing, to also flush & zero-pad a trailing partial byte.
+func (w *BitWriter) Flush() error {
+	n := w.offset >> 3
+	if n == 0 {
+		return nil
+	}
+
+	if _, err := w.underlying.Write(w.buffer[:n]); err != nil {
+		return err
+	}
+
+	if w.offset&7 != 0 {
+		// Carry the partial trailing byte over to the front of the buffer.
+		w.buffer[0] = w.buffer[n]
+	}
+	w.offset &= 7
+
+	return nil
+}
+
+// Close zero-pads and flushes any remaining bits, and closes the underlying writer if it
+// implements io.Closer. Open() may be used again after Close().
+func (w *BitWriter) Close() error {
+	if w.offset&7 != 0 {
+		pad := 8 - w.offset&7
+		w.offset += pad
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	underlying := w.underlying
+	w.underlying = nil
+	w.buffer = nil
+	w.offset = 0
+
+	if closer, ok := underlying.(io.Closer); ok {
+		return closer.Close()
+	}
+
+	return nil
+}
+
+func (w *BitWriter) advance(bits int) {
+	w.offset += bits
+	if w.offset>>3 >= len(w.buffer) {
+		if err := w.Flush(); err != nil {
+			panic(err)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package bitread_test
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/markus-wa/gobitread"
+)
+
+func TestWriteBit(t *testing.T) {
+	var buf bytes.Buffer
+
+	bw := new(bitread.BitWriter)
+	bw.Open(&buf, 32)
+
+	for n := byte(0); n < 0xff; n++ {
+		for i := 0; i < 8; i++ {
+			bw.WriteBit(n&(1<<uint(i)) != 0)
+		}
+	}
+	bw.Close()
+
+	br := new(bitread.BitReader)
+	br.Open(bytes.NewReader(buf.Bytes()), 32)
+
+	for n := byte(0); n < 0xff; n++ {
+		r := br.ReadSingleByte()
+		if r != n {
+			t.Fatalf("Expected %b got %b", n, r)
+		}
+	}
+}
+
+func TestWriteIntRoundTrip(t *testing.T) {
+	nums := []uint{0, math.MaxUint32, 0x61cb83f0}
+
+	var buf bytes.Buffer
+	bw := new(bitread.BitWriter)
+	bw.Open(&buf, 32)
+
+	for _, n := range nums {
+		bw.WriteInt(n, 32)
+	}
+	bw.Close()
+
+	br := new(bitread.BitReader)
+	br.Open(bytes.NewReader(buf.Bytes()), 32)
+
+	for _, n := range nums {
+		r := br.ReadInt(32)
+		if r != n {
+			t.Fatalf("Expected %d got %d", n, r)
+		}
+	}
+}
+
+func TestWriteIntRoundTripMSBFirst(t *testing.T) {
+	nums := []uint{0, math.MaxUint32, 0x61cb83f0}
+
+	var buf bytes.Buffer
+	bw := new(bitread.BitWriter)
+	bw.OpenWithOptions(&buf, bitread.BitWriterOptions{BufferSize: 32, BitOrder: bitread.MSBFirst})
+
+	for _, n := range nums {
+		bw.WriteInt(n, 32)
+	}
+	bw.Close()
+
+	br := new(bitread.BitReader)
+	br.OpenWithOptions(bytes.NewReader(buf.Bytes()), bitread.BitReaderOptions{BufferSize: 32, BitOrder: bitread.MSBFirst})
+
+	for _, n := range nums {
+		r := br.ReadInt(32)
+		if r != n {
+			t.Fatalf("Expected %d got %d", n, r)
+		}
+	}
+}
+
+func TestWriteSignedIntRoundTrip(t *testing.T) {
+	nums := []int{math.MaxInt32, math.MinInt32, 0, 0x4ac71bf}
+
+	var buf bytes.Buffer
+	bw := new(bitread.BitWriter)
+	bw.Open(&buf, 32)
+
+	for _, n := range nums {
+		bw.WriteSignedInt(n, 32)
+	}
+	bw.Close()
+
+	br := new(bitread.BitReader)
+	br.Open(bytes.NewReader(buf.Bytes()), 32)
+
+	for _, n := range nums {
+		r := br.ReadSignedInt(32)
+		if r != n {
+			t.Fatalf("Expected %d got %d", n, r)
+		}
+	}
+}
+
+func TestWriteCStringRoundTrip(t *testing.T) {
+	s := "test"
+
+	var buf bytes.Buffer
+	bw := new(bitread.BitWriter)
+	bw.Open(&buf, 32)
+
+	bw.WriteCString(s, len(s)+4)
+	bw.Close()
+
+	br := new(bitread.BitReader)
+	br.Open(bytes.NewReader(buf.Bytes()), 32)
+
+	r := br.ReadCString(len(s) + 4)
+	if r != s {
+		t.Fatalf("Expected %q got %q", s, r)
+	}
+}
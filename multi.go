@@ -0,0 +1,72 @@
+package bitread
+
+import "io"
+
+// MultiBitReader concatenates a slice of SectionBitReaders into one logical bit stream,
+// stitching bits across section boundaries even when a section doesn't end byte-aligned.
+type MultiBitReader struct {
+	BitReader
+}
+
+// NewMultiBitReader creates a MultiBitReader over the concatenation of sections, in order.
+// bufferSize is passed through to the underlying BitReader the same way as in Open, and must
+// be a multiple of 8 and > 16.
+func NewMultiBitReader(sections []*SectionBitReader, bufferSize int) *MultiBitReader {
+	mr := &MultiBitReader{}
+	// Matches SectionBitReader: bit 0 of the concatenated stream ends up as the most
+	// significant bit of the first reconstructed byte, so read back MSB-first.
+	mr.OpenWithOptions(&multiReader{sections: sections}, BitReaderOptions{
+		BufferSize: bufferSize,
+		BitOrder:   MSBFirst,
+	})
+	return mr
+}
+
+// multiReader adapts a slice of SectionBitReaders to a single io.Reader, so it can be used to
+// back a BitReader. Bits are pulled from the sections one at a time, so a segment ending
+// mid-byte doesn't force byte-alignment on the next one.
+type multiReader struct {
+	sections []*SectionBitReader
+	idx      int
+}
+
+func (m *multiReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+
+	bitsNeeded := len(p) << 3
+	bitsWritten := 0
+
+	for bitsWritten < bitsNeeded {
+		bit, ok := m.readBits()
+		if !ok {
+			break
+		}
+		if bit {
+			p[bitsWritten>>3] |= 1 << uint(7-bitsWritten&7)
+		}
+		bitsWritten++
+	}
+
+	if bitsWritten == 0 {
+		return 0, io.EOF
+	}
+
+	return (bitsWritten + 7) >> 3, nil
+}
+
+// readBits returns the next bit from the current section, transparently advancing to the
+// next section (buffering the up-to-7 leftover bits of a mid-byte section boundary inside the
+// section's own BitReader state) once the current one is exhausted.
+func (m *multiReader) readBits() (bit bool, ok bool) {
+	for m.idx < len(m.sections) {
+		s := m.sections[m.idx]
+		if s.Remaining() <= 0 {
+			m.idx++
+			continue
+		}
+		return s.ReadBit(), true
+	}
+	return false, false
+}
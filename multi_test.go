@@ -0,0 +1,32 @@
+package bitread_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/markus-wa/gobitread"
+)
+
+func TestMultiBitReader(t *testing.T) {
+	s1 := bitread.NewSectionBitReader(bytes.NewReader([]byte{0xac}), 0, 4, 32)
+	s2 := bitread.NewSectionBitReader(bytes.NewReader([]byte{0x3f}), 0, 4, 32)
+
+	mr := bitread.NewMultiBitReader([]*bitread.SectionBitReader{s1, s2}, 32)
+
+	r := mr.ReadSingleByte()
+	if r != 0xa3 {
+		t.Fatalf("Expected %#x got %#x", 0xa3, r)
+	}
+}
+
+func TestMultiBitReaderByteAligned(t *testing.T) {
+	s1 := bitread.NewSectionBitReader(bytes.NewReader([]byte{0x12, 0x34}), 0, 16, 32)
+	s2 := bitread.NewSectionBitReader(bytes.NewReader([]byte{0x56, 0x78}), 0, 16, 32)
+
+	mr := bitread.NewMultiBitReader([]*bitread.SectionBitReader{s1, s2}, 32)
+
+	r := mr.ReadBytes(4)
+	if !bytes.Equal(r, []byte{0x12, 0x34, 0x56, 0x78}) {
+		t.Fatalf("Expected %x got %x", []byte{0x12, 0x34, 0x56, 0x78}, r)
+	}
+}
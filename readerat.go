@@ -0,0 +1,80 @@
+package bitread
+
+import "io"
+
+// BitReaderAt provides bit-precise random-access reads over an io.ReaderAt and, unlike the
+// stateful BitReader, is safe for concurrent use: every call does its own ReadAt into a small
+// local scratch buffer and never mutates shared state.
+//
+// Bits are numbered from the most significant bit of the first byte, matching
+// SectionBitReader and MultiBitReader.
+type BitReaderAt struct {
+	r       io.ReaderAt
+	lenBits int64
+}
+
+// NewBitReaderAt creates a BitReaderAt over r, which is lenBits bits long in total.
+func NewBitReaderAt(r io.ReaderAt, lenBits int64) *BitReaderAt {
+	return &BitReaderAt{r: r, lenBits: lenBits}
+}
+
+// Len returns the total length of the underlying data in bits.
+func (r *BitReaderAt) Len() int64 {
+	return r.lenBits
+}
+
+// ReadBitsAt reads nBits bits starting at bitOff into p, which must be at least
+// (nBits+7)/8 bytes long. Returns the number of bytes written to p. Safe for concurrent use.
+func (r *BitReaderAt) ReadBitsAt(p []byte, nBits int, bitOff int64) (n int, err error) {
+	if bitOff < 0 || nBits < 0 || bitOff+int64(nBits) > r.lenBits {
+		return 0, io.ErrUnexpectedEOF
+	}
+
+	n = (nBits + 7) >> 3
+	byteOff := bitOff >> 3
+	bitShift := uint(bitOff & 7)
+
+	// Fetch one extra byte to have enough bits to shift from, unless byte-aligned.
+	tmp := make([]byte, n+1)
+	read, err := r.r.ReadAt(tmp, byteOff)
+	if err != nil && err != io.EOF {
+		return 0, err
+	}
+	if read < n {
+		return 0, io.ErrUnexpectedEOF
+	}
+
+	if bitShift == 0 {
+		copy(p, tmp[:n])
+	} else {
+		for i := 0; i < n; i++ {
+			p[i] = tmp[i]<<bitShift | tmp[i+1]>>(8-bitShift)
+		}
+	}
+
+	if tailBits := nBits & 7; tailBits != 0 {
+		p[n-1] &= 0xff << uint(8-tailBits)
+	}
+
+	return n, nil
+}
+
+// ReadIntAt reads the n-bit unsigned int at bitOff. Undefined for n > 32. Safe for
+// concurrent use.
+func (r *BitReaderAt) ReadIntAt(n int, bitOff int64) (uint, error) {
+	buf := make([]byte, (n+7)>>3)
+	if _, err := r.ReadBitsAt(buf, n, bitOff); err != nil {
+		return 0, err
+	}
+
+	var val uint64
+	for _, b := range buf {
+		val = val<<8 | uint64(b)
+	}
+	if tailBits := n & 7; tailBits != 0 {
+		// The last byte's low bits are padding, added by ReadBitsAt - shift them out.
+		val >>= uint(8 - tailBits)
+	}
+
+	return uint(val), nil
+}
@@ -0,0 +1,59 @@
+package bitread_test
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/markus-wa/gobitread"
+)
+
+func TestBitReaderAtReadIntAt(t *testing.T) {
+	b := []byte{0xac, 0x3f}
+
+	ra := bitread.NewBitReaderAt(bytes.NewReader(b), int64(len(b)<<3))
+
+	r, err := ra.ReadIntAt(8, 4)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if r != 0xc3 {
+		t.Fatalf("Expected %#x got %#x", 0xc3, r)
+	}
+}
+
+func TestBitReaderAtOutOfRange(t *testing.T) {
+	b := []byte{0xac}
+
+	ra := bitread.NewBitReaderAt(bytes.NewReader(b), int64(len(b)<<3))
+
+	if _, err := ra.ReadIntAt(8, 4); err == nil {
+		t.Fatal("Expected an error reading past the end")
+	}
+}
+
+func TestBitReaderAtConcurrent(t *testing.T) {
+	b := make([]byte, 1<<10)
+	for i := range b {
+		b[i] = byte(i)
+	}
+
+	ra := bitread.NewBitReaderAt(bytes.NewReader(b), int64(len(b)<<3))
+
+	var wg sync.WaitGroup
+	for i := 0; i < len(b); i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			r, err := ra.ReadIntAt(8, int64(i<<3))
+			if err != nil {
+				t.Errorf("Unexpected error %v", err)
+				return
+			}
+			if r != uint(b[i]) {
+				t.Errorf("Expected %#x got %#x", b[i], r)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
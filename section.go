@@ -0,0 +1,90 @@
+package bitread
+
+import "io"
+
+// SectionBitReader is a BitReader that exposes a window [startBit, startBit+lenBits) of an
+// underlying io.ReaderAt as its own bit stream, without copying the underlying data.
+//
+// This is useful for decoding a compound container whose fields are chunks of a parent
+// buffer, e.g. BeginChunk/EndChunk returning a scoped sub-reader.
+type SectionBitReader struct {
+	BitReader
+	lenBits int
+}
+
+// NewSectionBitReader creates a SectionBitReader over [startBit, startBit+lenBits) of r.
+// bufferSize is passed through to the underlying BitReader the same way as in Open, and must
+// be a multiple of 8 and > 16.
+func NewSectionBitReader(r io.ReaderAt, startBit int, lenBits int, bufferSize int) *SectionBitReader {
+	sr := &SectionBitReader{lenBits: lenBits}
+	// The adapter below reconstructs bytes with bit 0 of the section as the most significant
+	// bit of the first reconstructed byte, so the underlying BitReader must read MSB-first to
+	// hand out the section's bits in the order they occur in the parent buffer.
+	sr.OpenWithOptions(&sectionReader{r: r, startBit: startBit, lenBits: lenBits}, BitReaderOptions{
+		BufferSize: bufferSize,
+		BitOrder:   MSBFirst,
+	})
+	return sr
+}
+
+// Len returns the length of this section in bits.
+func (r *SectionBitReader) Len() int {
+	return r.lenBits
+}
+
+// Remaining returns the number of unread bits left in this section.
+func (r *SectionBitReader) Remaining() int {
+	return r.lenBits - r.ActualPosition()
+}
+
+// sectionReader adapts a bit range of an io.ReaderAt to an io.Reader, so it can be used to
+// back a BitReader. Bits are numbered from the most significant bit of the first byte.
+type sectionReader struct {
+	r        io.ReaderAt
+	startBit int
+	lenBits  int
+	bitPos   int // Bits already handed out via Read.
+}
+
+func (s *sectionReader) Read(p []byte) (int, error) {
+	remainingBits := s.lenBits - s.bitPos
+	if remainingBits <= 0 {
+		return 0, io.EOF
+	}
+
+	n := len(p)
+	if n<<3 > remainingBits {
+		n = (remainingBits + 7) >> 3
+	}
+
+	absBit := s.startBit + s.bitPos
+	byteOff := int64(absBit >> 3)
+	bitShift := uint(absBit & 7)
+
+	// Fetch one extra byte to have enough bits to shift from, unless byte-aligned.
+	tmp := make([]byte, n+1)
+	read, err := s.r.ReadAt(tmp, byteOff)
+	if err != nil && err != io.EOF {
+		return 0, err
+	}
+	if read < n {
+		return 0, io.ErrUnexpectedEOF
+	}
+
+	if bitShift == 0 {
+		copy(p, tmp[:n])
+	} else {
+		for i := 0; i < n; i++ {
+			p[i] = tmp[i]<<bitShift | tmp[i+1]>>(8-bitShift)
+		}
+	}
+
+	// Zero out trailing bits beyond the section's length in the final byte.
+	if tailBits := remainingBits & 7; tailBits != 0 && n<<3 >= remainingBits {
+		p[n-1] &= 0xff << uint(8-tailBits)
+	}
+
+	s.bitPos += n << 3
+
+	return n, nil
+}
@@ -0,0 +1,34 @@
+package bitread_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/markus-wa/gobitread"
+)
+
+func TestSectionBitReader(t *testing.T) {
+	b := []byte{0xac, 0x3f}
+
+	sr := bitread.NewSectionBitReader(bytes.NewReader(b), 4, 8, 32)
+
+	r := sr.ReadSingleByte()
+	if r != 0xc3 {
+		t.Fatalf("Expected %#x got %#x", 0xc3, r)
+	}
+
+	if sr.Remaining() != 0 {
+		t.Fatalf("Expected 0 bits remaining, got %d", sr.Remaining())
+	}
+}
+
+func TestSectionBitReaderByteAligned(t *testing.T) {
+	b := []byte{0x12, 0x34, 0x56}
+
+	sr := bitread.NewSectionBitReader(bytes.NewReader(b), 8, 16, 32)
+
+	r := sr.ReadBytes(2)
+	if !bytes.Equal(r, []byte{0x34, 0x56}) {
+		t.Fatalf("Expected %x got %x", []byte{0x34, 0x56}, r)
+	}
+}